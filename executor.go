@@ -0,0 +1,130 @@
+// Copyright 2021 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/alecthomas/kingpin/v2"
+
+	"github.com/prometheus-community/ipmi_exporter/freeipmi"
+)
+
+var (
+	recordFixtures = kingpin.Flag("record", "Record every freeipmi.Execute invocation made by --test to testdata/<fixture>.golden, while still collecting from the real tools.").Bool()
+	replayFixtures = kingpin.Flag("replay", "Replay freeipmi.Execute invocations made by --test from testdata/<fixture>.golden instead of shelling out to the real tools.").Bool()
+)
+
+// Executor abstracts how a FreeIPMI command's output is obtained, so RunTest
+// can be pointed at either the real tools or a golden fixture without any
+// change to collector code.
+type Executor interface {
+	Execute(cmd string, args []string, cfg string, target string) freeipmi.Result
+}
+
+// realExecutor shells out to the FreeIPMI command-line tools, exactly as
+// RunTest always has. logger is a concrete *slog.Logger because that's what
+// freeipmi.Execute requires; executorFor adapts each test case's logging.Logger
+// sink into one via testSink.SlogLogger.
+type realExecutor struct {
+	logger *slog.Logger
+}
+
+func (e realExecutor) Execute(cmd string, args []string, cfg string, target string) freeipmi.Result {
+	return freeipmi.Execute(cmd, args, cfg, target, e.logger)
+}
+
+// goldenFixture is the on-disk shape of one recorded freeipmi.Execute call.
+type goldenFixture struct {
+	Cmd        string   `json:"cmd"`
+	Args       []string `json:"args"`
+	Stdout     []byte   `json:"stdout"`
+	Stderr     string   `json:"stderr"`
+	ExitStatus int      `json:"exit_status"`
+}
+
+// recordingExecutor wraps another Executor and writes a golden fixture for
+// every call it makes, so a `--test --record` run seeds the fixtures a later
+// `--test --replay` run reads back.
+type recordingExecutor struct {
+	inner   Executor
+	fixture string
+}
+
+func (e recordingExecutor) Execute(cmd string, args []string, cfg string, target string) freeipmi.Result {
+	result := e.inner.Execute(cmd, args, cfg, target)
+	fixture := goldenFixture{
+		Cmd:        cmd,
+		Args:       args,
+		Stdout:     result.RawStdout(),
+		Stderr:     result.RawStderr(),
+		ExitStatus: result.ExitStatus(),
+	}
+	if err := writeGoldenFixture(e.fixture, fixture); err != nil {
+		// Recording is best-effort: a write failure shouldn't fail the test
+		// run that's exercising the real hardware.
+		fmt.Fprintf(os.Stderr, "warning: failed to record fixture %q: %v\n", e.fixture, err)
+	}
+	return result
+}
+
+// FixtureExecutor replays a previously recorded golden fixture instead of
+// invoking any FreeIPMI tool, making the test suite hermetic and its bug
+// reports reproducible from an attached tarball of goldens.
+type FixtureExecutor struct {
+	fixture string
+}
+
+func (e FixtureExecutor) Execute(cmd string, args []string, cfg string, target string) freeipmi.Result {
+	fixture, err := readGoldenFixture(e.fixture)
+	if err != nil {
+		return freeipmi.NewResultWithStatus(nil, "", 0, fmt.Errorf("replay fixture %q: %w", e.fixture, err))
+	}
+	var replayErr error
+	if fixture.ExitStatus != 0 {
+		replayErr = fmt.Errorf("replayed fixture %q exited %d: %s", e.fixture, fixture.ExitStatus, fixture.Stderr)
+	}
+	return freeipmi.NewResultWithStatus(fixture.Stdout, fixture.Stderr, fixture.ExitStatus, replayErr)
+}
+
+func goldenPath(fixture string) string {
+	return filepath.Join("testdata", fixture+".golden")
+}
+
+func writeGoldenFixture(fixture string, data goldenFixture) error {
+	if err := os.MkdirAll("testdata", 0o755); err != nil {
+		return err
+	}
+	encoded, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(goldenPath(fixture), encoded, 0o644)
+}
+
+func readGoldenFixture(fixture string) (goldenFixture, error) {
+	data, err := os.ReadFile(goldenPath(fixture))
+	if err != nil {
+		return goldenFixture{}, err
+	}
+	var decoded goldenFixture
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return goldenFixture{}, err
+	}
+	return decoded, nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2021 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestGoldenFixtureRoundTrip(t *testing.T) {
+	const fixtureName = "executor_roundtrip_test"
+	t.Cleanup(func() { os.Remove(goldenPath(fixtureName)) })
+
+	want := goldenFixture{
+		Cmd:        "ipmi-sensors",
+		Args:       []string{"--no-header-output", "-D", "LAN_2_0"},
+		Stdout:     []byte("1,Temp,30.00,C,OK\n"),
+		Stderr:     "",
+		ExitStatus: 0,
+	}
+
+	if err := writeGoldenFixture(fixtureName, want); err != nil {
+		t.Fatalf("writeGoldenFixture: %v", err)
+	}
+
+	got, err := readGoldenFixture(fixtureName)
+	if err != nil {
+		t.Fatalf("readGoldenFixture: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("readGoldenFixture = %+v, want %+v", got, want)
+	}
+}
+
+func TestFixtureExecutorReplaysWrittenFixture(t *testing.T) {
+	const fixtureName = "executor_replay_test"
+	t.Cleanup(func() { os.Remove(goldenPath(fixtureName)) })
+
+	recorded := goldenFixture{
+		Cmd:        "ipmi-sensors",
+		Args:       nil,
+		Stdout:     []byte("recorded output"),
+		Stderr:     "",
+		ExitStatus: 0,
+	}
+	if err := writeGoldenFixture(fixtureName, recorded); err != nil {
+		t.Fatalf("writeGoldenFixture: %v", err)
+	}
+
+	result := (FixtureExecutor{fixture: fixtureName}).Execute("ipmi-sensors", nil, "", "127.0.0.1")
+	if result.Err() != nil {
+		t.Fatalf("Execute: %v", result.Err())
+	}
+	if string(result.RawStdout()) != "recorded output" {
+		t.Errorf("RawStdout = %q, want %q", result.RawStdout(), "recorded output")
+	}
+}
+
+func TestFixtureExecutorSurfacesNonZeroExitStatus(t *testing.T) {
+	const fixtureName = "executor_replay_failure_test"
+	t.Cleanup(func() { os.Remove(goldenPath(fixtureName)) })
+
+	recorded := goldenFixture{
+		Cmd:        "ipmi-sensors",
+		Stderr:     "command failed",
+		ExitStatus: 1,
+	}
+	if err := writeGoldenFixture(fixtureName, recorded); err != nil {
+		t.Fatalf("writeGoldenFixture: %v", err)
+	}
+
+	result := (FixtureExecutor{fixture: fixtureName}).Execute("ipmi-sensors", nil, "", "127.0.0.1")
+	if result.Err() == nil {
+		t.Fatal("Execute: expected an error for a fixture recorded with a non-zero exit status")
+	}
+}
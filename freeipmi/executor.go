@@ -0,0 +1,49 @@
+// Copyright 2021 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package freeipmi
+
+import (
+	"bytes"
+	"log/slog"
+	"os/exec"
+)
+
+// Execute shells out to a FreeIPMI command-line tool (ipmimonitoring,
+// ipmi-sensors, bmc-info, ...) and captures its output as a Result. cfg is
+// the path to the FreeIPMI config file to pass via --config-file, and may be
+// empty to use the tool's defaults; target is the BMC being queried, logged
+// for context but not otherwise interpreted here. logger is a concrete
+// *slog.Logger, not the logging.Logger interface used elsewhere in this
+// repo: callers that only have a logging.Logger (e.g. a per-test capture
+// sink) must adapt it to a *slog.Logger before calling Execute.
+func Execute(cmd string, args []string, cfg string, target string, logger *slog.Logger) Result {
+	if cfg != "" {
+		args = append([]string{"--config-file", cfg}, args...)
+	}
+
+	logger.Debug("executing freeipmi command", "cmd", cmd, "args", args, "target", target)
+
+	var stdout, stderr bytes.Buffer
+	execCmd := exec.Command(cmd, args...)
+	execCmd.Stdout = &stdout
+	execCmd.Stderr = &stderr
+
+	err := execCmd.Run()
+	exitStatus := 0
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		exitStatus = exitErr.ExitCode()
+	}
+
+	return NewResultWithStatus(stdout.Bytes(), stderr.String(), exitStatus, err)
+}
@@ -0,0 +1,58 @@
+// Copyright 2021 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package freeipmi
+
+// Result holds the raw output of an IPMI command execution, whether it came
+// from shelling out to a FreeIPMI tool, from the native RMCP+ client in
+// package nativeipmi, or from a replayed golden fixture.
+type Result struct {
+	stdout     []byte
+	stderr     string
+	exitStatus int
+	err        error
+}
+
+// NewResult builds a Result from just the output bytes and an error,
+// suitable for callers (like the native RMCP+ client) that have no separate
+// notion of stderr or a process exit status.
+func NewResult(output []byte, err error) Result {
+	return Result{stdout: output, err: err}
+}
+
+// NewResultWithStatus builds a Result carrying the full detail a recorded or
+// replayed FreeIPMI invocation has: stdout, stderr, and the process exit
+// status, in addition to any error.
+func NewResultWithStatus(stdout []byte, stderr string, exitStatus int, err error) Result {
+	return Result{stdout: stdout, stderr: stderr, exitStatus: exitStatus, err: err}
+}
+
+// RawStdout returns the captured standard output.
+func (r Result) RawStdout() []byte {
+	return r.stdout
+}
+
+// RawStderr returns the captured standard error, if any was recorded.
+func (r Result) RawStderr() string {
+	return r.stderr
+}
+
+// ExitStatus returns the process exit status, if any was recorded.
+func (r Result) ExitStatus() int {
+	return r.exitStatus
+}
+
+// Err returns the error associated with this result, if any.
+func (r Result) Err() error {
+	return r.err
+}
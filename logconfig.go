@@ -0,0 +1,80 @@
+// Copyright 2021 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/alecthomas/kingpin/v2"
+
+	"github.com/prometheus-community/ipmi_exporter/logging"
+)
+
+var (
+	logLevel  = kingpin.Flag("log.level", "Only log messages with the given severity or above. One of: [debug, info, warn, error]").Default("info").Enum("debug", "info", "warn", "error")
+	logFormat = kingpin.Flag("log.format", "Output format of log messages. One of: [logfmt, json]").Default("logfmt").Enum("logfmt", "json")
+)
+
+// newDefaultLogger builds the slog.Logger backing the suite when the caller
+// doesn't supply its own, honoring --log.level and --log.format.
+func newDefaultLogger() logging.Logger {
+	opts := &slog.HandlerOptions{Level: parseLogLevel(*logLevel)}
+
+	var handler slog.Handler
+	if *logFormat == "json" {
+		handler = slog.NewJSONHandler(os.Stdout, opts)
+	} else {
+		handler = slog.NewTextHandler(os.Stdout, opts)
+	}
+	return slog.New(handler)
+}
+
+func parseLogLevel(level string) slog.Level {
+	switch strings.ToLower(level) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// isExpectedMissing reports whether err represents an IPMI sub-feature that
+// is simply unsupported on this BMC (an empty SEL, DCMI not implemented, an
+// optional BMC field absent, no native command for a feature) rather than a
+// transport or authentication failure. Callers downgrade these to Debug so
+// routine hardware variation doesn't drown out real problems.
+func isExpectedMissing(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	for _, marker := range []string{
+		"not supported",
+		"not implemented",
+		"no native",
+		"not available",
+		"unsupported",
+	} {
+		if strings.Contains(msg, marker) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,27 @@
+// Copyright 2021 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package logging defines the Logger interface shared across the exporter's
+// collectors, the native IPMI client, and the test suite, so none of them
+// need to depend on a concrete logging backend.
+package logging
+
+// Logger is satisfied by *slog.Logger as-is; it exists so packages that
+// only need to log don't have to import log/slog directly, and so the test
+// suite can wrap a Logger with a per-test capture sink.
+type Logger interface {
+	Debug(msg string, args ...any)
+	Info(msg string, args ...any)
+	Warn(msg string, args ...any)
+	Error(msg string, args ...any)
+}
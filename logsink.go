@@ -0,0 +1,104 @@
+// Copyright 2021 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+
+	"github.com/prometheus-community/ipmi_exporter/logging"
+)
+
+// testSink wraps a Logger and additionally captures every Debug-level
+// message logged through it, so a single test case's trace is self-contained
+// even when RunAllTests is running many test cases concurrently and sharing
+// one underlying logger.
+type testSink struct {
+	base logging.Logger
+
+	mu    sync.Mutex
+	lines []string
+}
+
+func newTestSink(base logging.Logger) *testSink {
+	return &testSink{base: base}
+}
+
+func (s *testSink) Debug(msg string, args ...any) {
+	s.mu.Lock()
+	s.lines = append(s.lines, formatLogLine(msg, args))
+	s.mu.Unlock()
+	s.base.Debug(msg, args...)
+}
+
+func (s *testSink) Info(msg string, args ...any)  { s.base.Info(msg, args...) }
+func (s *testSink) Warn(msg string, args ...any)  { s.base.Warn(msg, args...) }
+func (s *testSink) Error(msg string, args ...any) { s.base.Error(msg, args...) }
+
+// DebugTrace joins every Debug-level message captured so far into a single
+// string suitable for TestResult.Trace.
+func (s *testSink) DebugTrace() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return strings.Join(s.lines, "\n")
+}
+
+// SlogLogger adapts this sink into a concrete *slog.Logger, for the rare
+// callers (freeipmi.Execute) that require one instead of the logging.Logger
+// interface: every record handed to it is routed back through the sink's own
+// Debug/Info/Warn/Error, so Debug-level records are still captured in
+// DebugTrace.
+func (s *testSink) SlogLogger() *slog.Logger {
+	return slog.New(&testSinkHandler{sink: s})
+}
+
+type testSinkHandler struct {
+	sink *testSink
+}
+
+func (h *testSinkHandler) Enabled(_ context.Context, _ slog.Level) bool { return true }
+
+func (h *testSinkHandler) Handle(_ context.Context, r slog.Record) error {
+	args := make([]any, 0, r.NumAttrs()*2)
+	r.Attrs(func(a slog.Attr) bool {
+		args = append(args, a.Key, a.Value.Any())
+		return true
+	})
+	switch {
+	case r.Level < slog.LevelInfo:
+		h.sink.Debug(r.Message, args...)
+	case r.Level < slog.LevelWarn:
+		h.sink.Info(r.Message, args...)
+	case r.Level < slog.LevelError:
+		h.sink.Warn(r.Message, args...)
+	default:
+		h.sink.Error(r.Message, args...)
+	}
+	return nil
+}
+
+func (h *testSinkHandler) WithAttrs(_ []slog.Attr) slog.Handler { return h }
+func (h *testSinkHandler) WithGroup(_ string) slog.Handler      { return h }
+
+func formatLogLine(msg string, args []any) string {
+	var b strings.Builder
+	b.WriteString(msg)
+	for i := 0; i+1 < len(args); i += 2 {
+		fmt.Fprintf(&b, " %v=%v", args[i], args[i+1])
+	}
+	return b.String()
+}
@@ -0,0 +1,110 @@
+// Copyright 2021 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nativeipmi
+
+import "fmt"
+
+// GetDeviceID issues Get Device ID (app, 0x01) and returns the raw response
+// bytes, in the same field order FreeIPMI's bmc-info would print them.
+func (s *Session) GetDeviceID() Result {
+	data, err := s.sendCommand(netFnApp, cmdGetDeviceID, nil)
+	return newResult(data, err)
+}
+
+// GetChassisStatus issues Get Chassis Status (chassis, 0x01).
+func (s *Session) GetChassisStatus() Result {
+	data, err := s.sendCommand(netFnChassis, cmdGetChassisStatus, nil)
+	return newResult(data, err)
+}
+
+// GetDCMIPowerReading issues the DCMI Get Power Reading command, which is
+// carried as a group-extension command under the DCMI group-extension
+// network function (netFnDCMI).
+func (s *Session) GetDCMIPowerReading() Result {
+	data, err := s.sendCommand(netFnDCMI, cmdDCMIGetPowerReading, []byte{groupExtensionDCMI, 0x01, 0x00, 0x00})
+	return newResult(data, err)
+}
+
+// GetSensorReadings reserves the SDR repository, walks it with Get SDR, and
+// issues Get Sensor Reading for every record found; records are not filtered
+// by type, and readings that fail are skipped. The concatenated readings
+// mirror what `ipmi-sensors` would print per sensor.
+func (s *Session) GetSensorReadings() Result {
+	if _, err := s.sendCommand(netFnStorage, cmdReserveSDRRepository, nil); err != nil {
+		return newResult(nil, fmt.Errorf("reserve SDR repository: %w", err))
+	}
+
+	var out []byte
+	recordID := uint16(0)
+	for {
+		sdr, err := s.sendCommand(netFnStorage, cmdGetSDR, []byte{0x00, 0x00, byte(recordID), byte(recordID >> 8), 0x00, 0xff})
+		if err != nil {
+			return newResult(out, fmt.Errorf("get SDR 0x%04x: %w", recordID, err))
+		}
+		if len(sdr) < 7 {
+			break
+		}
+		nextRecordID := uint16(sdr[0]) | uint16(sdr[1])<<8
+		sensorNumber := sdr[6]
+
+		reading, err := s.sendCommand(netFnSensorEvent, cmdGetSensorReading, []byte{sensorNumber})
+		if err == nil {
+			out = append(out, reading...)
+		}
+
+		if nextRecordID == 0xffff || nextRecordID == recordID {
+			break
+		}
+		recordID = nextRecordID
+	}
+	return newResult(out, nil)
+}
+
+// GetSEL issues Get SEL Info and then walks the log with Get SEL Entry,
+// returning the SEL Info response followed by every entry's raw bytes.
+func (s *Session) GetSEL() Result {
+	info, err := s.sendCommand(netFnStorage, cmdGetSELInfo, nil)
+	if err != nil {
+		return newResult(nil, fmt.Errorf("get SEL info: %w", err))
+	}
+	if len(info) < 3 || info[1] == 0 && info[2] == 0 {
+		return newResult(info, nil) // empty log: not an error, just nothing to report
+	}
+
+	if _, err := s.sendCommand(netFnStorage, cmdReserveSEL, nil); err != nil {
+		return newResult(info, fmt.Errorf("reserve SEL: %w", err))
+	}
+
+	out := append([]byte{}, info...)
+	recordID := uint16(0)
+	for {
+		entry, err := s.sendCommand(netFnStorage, cmdGetSELEntry, []byte{0x00, 0x00, byte(recordID), byte(recordID >> 8), 0x00, 0xff})
+		if err != nil || len(entry) < 2 {
+			break
+		}
+		out = append(out, entry...)
+		nextRecordID := uint16(entry[0]) | uint16(entry[1])<<8
+		if nextRecordID == 0xffff || nextRecordID == recordID {
+			break
+		}
+		recordID = nextRecordID
+	}
+	return newResult(out, nil)
+}
+
+// GetWatchdogTimer issues Get Watchdog Timer (app, 0x25).
+func (s *Session) GetWatchdogTimer() Result {
+	data, err := s.sendCommand(netFnApp, cmdGetWatchdogTimer, nil)
+	return newResult(data, err)
+}
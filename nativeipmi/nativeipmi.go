@@ -0,0 +1,75 @@
+// Copyright 2021 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package nativeipmi implements a minimal pure-Go IPMI v2.0 / RMCP+ client.
+//
+// It is a transport of last resort for environments where the FreeIPMI
+// command-line tools are unavailable: it speaks enough of the RMCP+
+// session-establishment handshake (cipher suite 3, HMAC-SHA1 authentication,
+// AES-CBC-128 confidentiality) and enough IPMI commands to satisfy the
+// `_native` test cases and collectors, but it is not a general-purpose IPMI
+// library.
+package nativeipmi
+
+import "time"
+
+const (
+	rmcpPort = 623
+
+	rmcpClassASF  = 0x06
+	rmcpClassIPMI = 0x07
+
+	// NetFn/LUN pairs used by the commands this package implements.
+	netFnApp         = 0x06
+	netFnChassis     = 0x00
+	netFnSensorEvent = 0x04
+	netFnStorage     = 0x0a
+	netFnTransport   = 0x0c
+	netFnDCMI        = 0x2c
+	lunBMC           = 0x00
+
+	// payloadTypeIPMI tags a session-header payload as a plain IPMI message,
+	// as opposed to one of the RMCP+ session-establishment payload types
+	// below (cmdOpenSession, cmdRAKP1, cmdRAKP3).
+	payloadTypeIPMI = 0x00
+
+	// Commands.
+	cmdGetChannelAuthCapabilities = 0x38
+	cmdGetSessionChallenge        = 0x39
+	cmdOpenSession                = 0x10 // carried as an RMCP+ session payload type, not an app-req
+	cmdRAKP1                      = 0x12
+	cmdRAKP3                      = 0x14
+	cmdCloseSession               = 0x3c
+	cmdGetDeviceID                = 0x01
+	cmdGetChassisStatus           = 0x01
+	cmdGetDCMICapability          = 0x01
+	cmdDCMIGetPowerReading        = 0x02
+	cmdGetSDRRepositoryInfo       = 0x20
+	cmdReserveSDRRepository       = 0x22
+	cmdGetSDR                     = 0x23
+	cmdGetSensorReading           = 0x2d
+	cmdGetSELInfo                 = 0x40
+	cmdReserveSEL                 = 0x42
+	cmdGetSELEntry                = 0x43
+	cmdGetWatchdogTimer           = 0x25
+
+	groupExtensionDCMI = 0xdc
+
+	// Cipher suite 3: RAKP-HMAC-SHA1 authentication, HMAC-SHA1-96 integrity,
+	// AES-CBC-128 confidentiality. This is the suite most BMCs enable by
+	// default and the only one this package speaks.
+	cipherSuite3 = 3
+
+	defaultDialTimeout = 5 * time.Second
+	defaultReadTimeout = 5 * time.Second
+)
@@ -0,0 +1,34 @@
+// Copyright 2021 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nativeipmi
+
+import "github.com/prometheus-community/ipmi_exporter/freeipmi"
+
+// Result holds the raw bytes returned by a native IPMI command, plus any
+// transport/protocol error encountered while obtaining them.
+type Result struct {
+	raw []byte
+	err error
+}
+
+func newResult(raw []byte, err error) Result {
+	return Result{raw: raw, err: err}
+}
+
+// ToFreeIPMIResult adapts a native Result into a freeipmi.Result, so
+// collectors written against the FreeIPMI output format can consume native
+// command output without any change to their Collect signature.
+func (r Result) ToFreeIPMIResult() freeipmi.Result {
+	return freeipmi.NewResult(r.raw, r.err)
+}
@@ -0,0 +1,177 @@
+// Copyright 2021 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nativeipmi
+
+// This file implements the wire framing for RMCP, the RMCP+ session
+// establishment messages (Open Session / RAKP 1-4), and IPMI LAN request
+// packets. It intentionally only covers the subset of the specification
+// needed to authenticate with cipher suite 3 and carry app/chassis/storage
+// commands; it is not a general-purpose codec.
+
+func buildRMCPPacket(class byte, body []byte) []byte {
+	header := []byte{
+		0x06,  // version 1.0
+		0x00,  // reserved
+		0xff,  // sequence number: no ack requested
+		class, // message class (ASF or IPMI session)
+	}
+	return append(header, body...)
+}
+
+// sessionHeaderLen is the size, in bytes, of the IPMI 2.0 / RMCP+ session
+// header built by buildSessionHeader: AuthType/Format(1) + Payload Type(1) +
+// Session ID(4) + Session Sequence Number(4) + Payload Length(2).
+const sessionHeaderLen = 12
+
+// buildSessionHeader builds the IPMI 2.0 / RMCP+ session header that precedes
+// every message this package sends. sessionID and seq are both 0 for the
+// pre-session handshake messages (Get Channel Auth Capabilities, Open Session
+// Request, RAKP1, RAKP3), since no session exists yet to tag them with.
+func buildSessionHeader(payloadType byte, sessionID, seq uint32, payloadLen int) []byte {
+	header := []byte{0x06, payloadType} // AuthType/Format: RMCP+ session
+	header = append(header, encodeUint32(sessionID)...)
+	header = append(header, encodeUint32(seq)...)
+	header = append(header, byte(payloadLen), byte(payloadLen>>8)) // length, LS byte first
+	return header
+}
+
+func buildGetChannelAuthCapabilities() []byte {
+	// Carries a Get Channel Authentication Capabilities request for the
+	// current channel (0x0e) with the "request IPMI v2.0 extended data" bit
+	// set, tagged as a plain IPMI message payload (0x00).
+	payload := buildIPMIRequestMessage(netFnApp, lunBMC, cmdGetChannelAuthCapabilities, []byte{0x80 | 0x0e, 0x04})
+	return append(buildSessionHeader(payloadTypeIPMI, 0, 0, len(payload)), payload...)
+}
+
+func buildOpenSessionRequest(remoteConsoleSessionID uint32, cipherSuite byte) []byte {
+	body := []byte{0x00, 0x00, 0x00, 0x00} // message tag, requested max privilege, reserved
+	body = append(body, encodeUint32(remoteConsoleSessionID)...)
+	body = append(body, authPayload(cipherSuite)...)
+	body = append(body, integrityPayload(cipherSuite)...)
+	body = append(body, confidentialityPayload(cipherSuite)...)
+	return append(buildSessionHeader(cmdOpenSession, 0, 0, len(body)), body...)
+}
+
+func authPayload(cipherSuite byte) []byte {
+	return []byte{0x00, 0x00, 0x08, 0x00, cipherSuite, 0x00, 0x00, 0x00}
+}
+
+func integrityPayload(byte) []byte {
+	return []byte{0x01, 0x00, 0x08, 0x00, 0x01, 0x00, 0x00, 0x00} // HMAC-SHA1-96
+}
+
+func confidentialityPayload(byte) []byte {
+	return []byte{0x02, 0x00, 0x08, 0x00, 0x01, 0x00, 0x00, 0x00} // AES-CBC-128
+}
+
+func parseOpenSessionResponse(resp []byte) uint32 {
+	// session header (sessionHeaderLen bytes) + message tag/status/privilege/
+	// reserved (4 bytes) + remote console session ID (4 bytes) precede the
+	// managed system session ID.
+	const offset = sessionHeaderLen + 4 + 4
+	if len(resp) < offset+4 {
+		return 0
+	}
+	return decodeUint32(resp[offset : offset+4])
+}
+
+func buildRAKP1(managedSystemSessionID uint32, consoleRand []byte, username string) []byte {
+	body := []byte{0x00, 0x00, 0x00, 0x00} // message tag, reserved x3
+	body = append(body, encodeUint32(managedSystemSessionID)...)
+	body = append(body, consoleRand...)
+	body = append(body, 0x14, 0x00, 0x00, byte(len(username))) // requested role, reserved, name length
+	body = append(body, []byte(username)...)
+	return append(buildSessionHeader(cmdRAKP1, 0, 0, len(body)), body...)
+}
+
+func parseRAKP2(resp []byte) (bmcRand, bmcGUID, bmcHMAC []byte) {
+	const offset = sessionHeaderLen + 8 // session header + message tag/status/reserved/managed system session id... simplified fixed offset
+	if len(resp) < offset+16+16 {
+		return nil, nil, nil
+	}
+	bmcRand = resp[offset : offset+16]
+	bmcGUID = resp[offset+16 : offset+32]
+	bmcHMAC = resp[offset+32:]
+	return bmcRand, bmcGUID, bmcHMAC
+}
+
+func buildRAKP3(managedSystemSessionID uint32, rakp3HMAC []byte) []byte {
+	body := []byte{0x00, 0x00, 0x00, 0x00} // message tag, status, reserved x2
+	body = append(body, encodeUint32(managedSystemSessionID)...)
+	body = append(body, rakp3HMAC...)
+	return append(buildSessionHeader(cmdRAKP3, 0, 0, len(body)), body...)
+}
+
+// buildIPMICommand wraps an application request in an authenticated,
+// integrity-protected RMCP+ session header (AuthType 0x06, HMAC-SHA1-96).
+func buildIPMICommand(sessionID, seq uint32, sik []byte, netFn, lun, cmd byte, data []byte) []byte {
+	payload := buildIPMIRequestMessage(netFn, lun, cmd, data)
+
+	header := buildSessionHeader(payloadTypeIPMI, sessionID, seq, len(payload))
+	packet := append(header, payload...)
+
+	if len(sik) == 0 {
+		return packet
+	}
+	mac := hmacSHA1(sik, packet)
+	return append(packet, mac[:12]...) // HMAC-SHA1-96: only the first 12 bytes ride on the wire
+}
+
+func buildIPMIRequestMessage(netFn, lun, cmd byte, data []byte) []byte {
+	msg := []byte{0x20, netFn<<2 | lun} // responder addr (BMC), netFn/LUN
+	checksum1 := twosComplementChecksum(msg)
+	msg = append(msg, checksum1)
+	msg = append(msg, 0x81, 0x00) // requester addr, requester seq/LUN
+	msg = append(msg, cmd)
+	msg = append(msg, data...)
+	msg = append(msg, twosComplementChecksum(msg[3:]))
+	return msg
+}
+
+func parseIPMIResponse(resp []byte) (completionCode byte, data []byte, err error) {
+	// Skip the session header: AuthType(1) + PayloadType(1) + sessionID(4) +
+	// seq(4) + len(2).
+	if len(resp) < sessionHeaderLen {
+		return 0, nil, errShortResponse
+	}
+	payloadLen := int(resp[sessionHeaderLen-2]) | int(resp[sessionHeaderLen-1])<<8
+	if len(resp) < sessionHeaderLen+payloadLen {
+		return 0, nil, errShortResponse
+	}
+	payload := resp[sessionHeaderLen : sessionHeaderLen+payloadLen]
+
+	// payload: respAddr, netFn/LUN, checksum, reqAddr, reqSeq/LUN, cmd, completion code, data...
+	const msgHeaderLen = 6
+	if len(payload) < msgHeaderLen+1 {
+		return 0, nil, errShortResponse
+	}
+	completionCode = payload[msgHeaderLen]
+	return completionCode, payload[msgHeaderLen+1 : len(payload)-1], nil // trailing byte is the message checksum
+}
+
+func twosComplementChecksum(data []byte) byte {
+	var sum byte
+	for _, b := range data {
+		sum += b
+	}
+	return -sum
+}
+
+func encodeUint32(v uint32) []byte {
+	return []byte{byte(v), byte(v >> 8), byte(v >> 16), byte(v >> 24)}
+}
+
+func decodeUint32(b []byte) uint32 {
+	return uint32(b[0]) | uint32(b[1])<<8 | uint32(b[2])<<16 | uint32(b[3])<<24
+}
@@ -0,0 +1,141 @@
+// Copyright 2021 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nativeipmi
+
+import (
+	"bytes"
+	"testing"
+)
+
+// Each build... message must carry a distinct payload type tag right after
+// AuthType/Format, so a BMC (or a test asserting on the wire bytes) can tell
+// the handshake messages apart even though they otherwise share a shape.
+func TestBuildMessagesTagDistinctPayloadTypes(t *testing.T) {
+	cases := []struct {
+		name        string
+		packet      []byte
+		payloadType byte
+	}{
+		{"GetChannelAuthCapabilities", buildGetChannelAuthCapabilities(), payloadTypeIPMI},
+		{"OpenSessionRequest", buildOpenSessionRequest(0xdeadbeef, cipherSuite3), cmdOpenSession},
+		{"RAKP1", buildRAKP1(0x12345678, make([]byte, 16), "admin"), cmdRAKP1},
+		{"RAKP3", buildRAKP3(0x12345678, make([]byte, 20)), cmdRAKP3},
+	}
+
+	for _, c := range cases {
+		if len(c.packet) < sessionHeaderLen {
+			t.Fatalf("%s: packet shorter than session header: %d bytes", c.name, len(c.packet))
+		}
+		if c.packet[0] != 0x06 {
+			t.Errorf("%s: AuthType/Format byte = 0x%02x, want 0x06", c.name, c.packet[0])
+		}
+		if c.packet[1] != c.payloadType {
+			t.Errorf("%s: payload type byte = 0x%02x, want 0x%02x", c.name, c.packet[1], c.payloadType)
+		}
+		gotLen := int(c.packet[sessionHeaderLen-2]) | int(c.packet[sessionHeaderLen-1])<<8
+		if wantLen := len(c.packet) - sessionHeaderLen; gotLen != wantLen {
+			t.Errorf("%s: header payload length = %d, want %d", c.name, gotLen, wantLen)
+		}
+	}
+
+	// And the four messages must not all collapse to the same tag, which was
+	// the original bug: every build... function emitted an identical
+	// all-zero header regardless of message kind.
+	seen := map[byte]string{}
+	for _, c := range cases {
+		if prev, ok := seen[c.payloadType]; ok {
+			t.Errorf("%s and %s share payload type 0x%02x", prev, c.name, c.payloadType)
+		}
+		seen[c.payloadType] = c.name
+	}
+}
+
+func TestBuildIPMICommandParseIPMIResponseRoundTrip(t *testing.T) {
+	req := buildIPMICommand(0x11223344, 7, nil, netFnApp, lunBMC, cmdGetDeviceID, nil)
+	if req[1] != payloadTypeIPMI {
+		t.Fatalf("payload type = 0x%02x, want 0x%02x", req[1], payloadTypeIPMI)
+	}
+	if got := decodeUint32(req[2:6]); got != 0x11223344 {
+		t.Errorf("session ID = 0x%x, want 0x11223344", got)
+	}
+	if got := decodeUint32(req[6:10]); got != 7 {
+		t.Errorf("sequence number = %d, want 7", got)
+	}
+
+	// Build a synthetic response whose payload is longer than 255 bytes, to
+	// exercise the 2-byte (not 1-byte) payload length field.
+	wantData := bytes.Repeat([]byte{0xAB}, 300)
+	payload := []byte{0x81, 0x00, 0x00, 0x20, 0x00, cmdGetDeviceID, 0x00} // addr/netFn/checksum/addr/seq/cmd/completion-code
+	payload = append(payload, wantData...)
+	payload = append(payload, 0x00) // trailing message checksum, unchecked by parseIPMIResponse
+	resp := append(buildSessionHeader(payloadTypeIPMI, 0x11223344, 7, len(payload)), payload...)
+
+	completionCode, data, err := parseIPMIResponse(resp)
+	if err != nil {
+		t.Fatalf("parseIPMIResponse: %v", err)
+	}
+	if completionCode != 0x00 {
+		t.Errorf("completion code = 0x%02x, want 0x00", completionCode)
+	}
+	if !bytes.Equal(data, wantData) {
+		t.Errorf("data = %d bytes, want %d bytes", len(data), len(wantData))
+	}
+}
+
+func TestBuildOpenSessionRequestParseResponseRoundTrip(t *testing.T) {
+	req := buildOpenSessionRequest(0x11112222, cipherSuite3)
+	if req[1] != cmdOpenSession {
+		t.Fatalf("payload type = 0x%02x, want 0x%02x", req[1], cmdOpenSession)
+	}
+
+	const managedSystemSessionID = 0xcafebabe
+	body := []byte{0x00, 0x00, 0x00, 0x00} // message tag, status, privilege, reserved
+	body = append(body, encodeUint32(0x11112222)...)
+	body = append(body, encodeUint32(managedSystemSessionID)...)
+	resp := append(buildSessionHeader(0x11, 0, 0, len(body)), body...)
+
+	if got := parseOpenSessionResponse(resp); got != managedSystemSessionID {
+		t.Errorf("managed system session ID = 0x%x, want 0x%x", got, uint32(managedSystemSessionID))
+	}
+}
+
+func TestBuildRAKP1ParseRAKP2RoundTrip(t *testing.T) {
+	consoleRand := bytes.Repeat([]byte{0x01}, 16)
+	req := buildRAKP1(0x12345678, consoleRand, "admin")
+	if req[1] != cmdRAKP1 {
+		t.Fatalf("payload type = 0x%02x, want 0x%02x", req[1], cmdRAKP1)
+	}
+
+	wantBMCRand := bytes.Repeat([]byte{0x02}, 16)
+	wantBMCGUID := bytes.Repeat([]byte{0x03}, 16)
+	wantBMCHMAC := bytes.Repeat([]byte{0x04}, 20)
+
+	body := []byte{0x00, 0x00, 0x00, 0x00} // message tag, status, reserved x2
+	body = append(body, encodeUint32(0x12345678)...)
+	body = append(body, wantBMCRand...)
+	body = append(body, wantBMCGUID...)
+	body = append(body, wantBMCHMAC...)
+	resp := append(buildSessionHeader(0x13, 0, 0, len(body)), body...)
+
+	bmcRand, bmcGUID, bmcHMAC := parseRAKP2(resp)
+	if !bytes.Equal(bmcRand, wantBMCRand) {
+		t.Errorf("bmcRand = %x, want %x", bmcRand, wantBMCRand)
+	}
+	if !bytes.Equal(bmcGUID, wantBMCGUID) {
+		t.Errorf("bmcGUID = %x, want %x", bmcGUID, wantBMCGUID)
+	}
+	if !bytes.Equal(bmcHMAC, wantBMCHMAC) {
+		t.Errorf("bmcHMAC = %x, want %x", bmcHMAC, wantBMCHMAC)
+	}
+}
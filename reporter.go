@@ -0,0 +1,257 @@
+// Copyright 2021 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/alecthomas/kingpin/v2"
+)
+
+var (
+	reportFormat = kingpin.Flag("report-format", "Output format for --test results.").Default("text").Enum("text", "json", "junit")
+	reportFile   = kingpin.Flag("report-file", "File to write --test results to. Defaults to stdout.").String()
+)
+
+// Reporter renders a TestSuite's accumulated results in a particular format.
+// TextReporter takes its pass/fail summary line from TestSuite.GetSummary.
+// JUnitReporter takes only the total duration from it and counts tests and
+// failures per collector result itself; JSONReporter emits no summary.
+type Reporter interface {
+	Report(ts *TestSuite, w io.Writer) error
+}
+
+// ReporterFor returns the Reporter registered for the given --report-format
+// value, falling back to TextReporter for anything unrecognized.
+func ReporterFor(format string) Reporter {
+	switch format {
+	case "json":
+		return JSONReporter{}
+	case "junit":
+		return JUnitReporter{}
+	default:
+		return TextReporter{}
+	}
+}
+
+// WriteReport renders ts with the Reporter for format to path, or to stdout
+// when path is empty.
+func WriteReport(ts *TestSuite, format, path string) error {
+	w := io.Writer(os.Stdout)
+	if path != "" {
+		f, err := os.Create(path)
+		if err != nil {
+			return fmt.Errorf("create report file %q: %w", path, err)
+		}
+		defer f.Close()
+		w = f
+	}
+	return ReporterFor(format).Report(ts, w)
+}
+
+// TextReporter renders the same ANSI-colored table PrintResultsTable has
+// always printed, just behind the Reporter interface and targeting an
+// arbitrary io.Writer instead of stdout.
+type TextReporter struct{}
+
+func (TextReporter) Report(ts *TestSuite, w io.Writer) error {
+	fmt.Fprintf(w, "\n========================================================================================================================\n")
+	fmt.Fprintf(w, "%-25s %-35s %-8s %-12s %-10s %-15s\n", "TEST NAME", "DESCRIPTION", "STATUS", "DURATION", "METRICS", "ERROR")
+	fmt.Fprintf(w, "------------------------------------------------------------------------------------------------------------------------\n")
+
+	for _, result := range ts.results {
+		collectorsUp := 0
+		for _, cr := range result.Collectors {
+			if cr.Up {
+				collectorsUp++
+			}
+		}
+
+		status := "FAIL"
+		statusColor := "\033[31m" // Red
+		if result.Passed {
+			statusColor = "\033[32m" // Green
+			if collectorsUp < len(result.Collectors) {
+				status = fmt.Sprintf("%d/%d", collectorsUp, len(result.Collectors))
+				statusColor = "\033[33m" // Yellow: partial pass
+			} else {
+				status = "PASS"
+			}
+		}
+
+		errorMsg := ""
+		if result.Error != nil {
+			errorMsg = result.Error.Error()
+			if len(errorMsg) > 15 {
+				errorMsg = errorMsg[:12] + "..."
+			}
+		}
+
+		description := result.TestCase.Description
+		if len(description) > 35 {
+			description = description[:32] + "..."
+		}
+
+		fmt.Fprintf(w, "%-25s %-35s %s%-8s\033[0m %-12v %-10d %-15s\n",
+			result.TestCase.Name,
+			description,
+			statusColor, status,
+			result.Duration,
+			result.MetricsCount,
+			errorMsg,
+		)
+	}
+
+	passed, failed, total, totalDuration := ts.GetSummary()
+	fmt.Fprintf(w, "------------------------------------------------------------------------------------------------------------------------\n")
+	fmt.Fprintf(w, "SUMMARY: %d PASSED, %d FAILED, %d TOTAL\n", passed, failed, total)
+	fmt.Fprintf(w, "TOTAL DURATION: %v\n", totalDuration)
+	fmt.Fprintf(w, "IMPLEMENTATION: %s\n", map[bool]string{true: "Native IPMI", false: "FreeIPMI"}[*nativeIPMI])
+	fmt.Fprintf(w, "========================================================================================================================\n")
+	return nil
+}
+
+// jsonCollectorResult is the JSON-friendly shape of a CollectorResult: error
+// is a string, since Go errors don't marshal meaningfully on their own.
+type jsonCollectorResult struct {
+	Name    string `json:"name"`
+	Up      bool   `json:"up"`
+	Metrics int    `json:"metrics"`
+	Error   string `json:"error,omitempty"`
+}
+
+type jsonTestResult struct {
+	Name            string                `json:"name"`
+	Description     string                `json:"description"`
+	Passed          bool                  `json:"passed"`
+	DurationSeconds float64               `json:"duration_seconds"`
+	MetricsCount    int                   `json:"metrics_count"`
+	Error           string                `json:"error,omitempty"`
+	Trace           string                `json:"trace,omitempty"`
+	Metrics         []string              `json:"metrics,omitempty"`
+	Collectors      []jsonCollectorResult `json:"collectors"`
+}
+
+// JSONReporter emits every TestResult as one element of a single JSON array:
+// name, description, outcome, duration, metric count, error, trace,
+// per-collector results, and the metric dump. TestResult.Output is not
+// included; the raw IPMI output is only present inside the metric dump,
+// which is filled in debug mode only.
+type JSONReporter struct{}
+
+func (JSONReporter) Report(ts *TestSuite, w io.Writer) error {
+	results := make([]jsonTestResult, 0, len(ts.results))
+	for _, r := range ts.results {
+		jr := jsonTestResult{
+			Name:            r.TestCase.Name,
+			Description:     r.TestCase.Description,
+			Passed:          r.Passed,
+			DurationSeconds: r.Duration.Seconds(),
+			MetricsCount:    r.MetricsCount,
+			Trace:           r.Trace,
+			Metrics:         r.Metrics,
+		}
+		if r.Error != nil {
+			jr.Error = r.Error.Error()
+		}
+		for _, cr := range r.Collectors {
+			jcr := jsonCollectorResult{Name: cr.Name, Up: cr.Up, Metrics: cr.Metrics}
+			if cr.Err != nil {
+				jcr.Error = cr.Err.Error()
+			}
+			jr.Collectors = append(jr.Collectors, jcr)
+		}
+		results = append(results, jr)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(results)
+}
+
+type junitTestSuite struct {
+	XMLName   xml.Name        `xml:"testsuite"`
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Time      float64         `xml:"time,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Content string `xml:",chardata"`
+}
+
+// JUnitReporter emits one <testcase classname="<collector>" name="<test>">
+// per collector result, so a CI system can tell which sub-feature of a
+// module failed rather than just that the test as a whole didn't pass.
+type JUnitReporter struct{}
+
+func (JUnitReporter) Report(ts *TestSuite, w io.Writer) error {
+	suite := junitTestSuite{Name: "ipmi_exporter"}
+
+	for _, r := range ts.results {
+		systemOut := ""
+		if ts.debug {
+			systemOut = strings.Join(r.Metrics, "\n")
+		}
+
+		for _, cr := range r.Collectors {
+			suite.Tests++
+			tc := junitTestCase{
+				ClassName: cr.Name,
+				Name:      r.TestCase.Name,
+				Time:      fmt.Sprintf("%.3f", r.Duration.Seconds()),
+				SystemOut: systemOut,
+			}
+			if !cr.Up {
+				suite.Failures++
+				message := "collector failed"
+				if cr.Err != nil {
+					message = cr.Err.Error()
+				}
+				tc.Failure = &junitFailure{Message: message, Content: r.Trace}
+			}
+			suite.TestCases = append(suite.TestCases, tc)
+		}
+	}
+
+	_, _, _, totalDuration := ts.GetSummary()
+	suite.Time = totalDuration.Seconds()
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(suite); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,100 @@
+// Copyright 2021 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"testing"
+	"time"
+)
+
+func sampleTestSuite() *TestSuite {
+	return &TestSuite{
+		results: []TestResult{
+			{
+				TestCase:     TestCase{Name: "default", Description: "Run every configured collector"},
+				Passed:       true,
+				Duration:     2 * time.Second,
+				MetricsCount: 3,
+				Collectors: []CollectorResult{
+					{Name: "bmc", Up: true, Metrics: 2},
+					{Name: "chassis", Up: false, Metrics: 0, Err: errors.New("chassis info unavailable")},
+				},
+			},
+		},
+	}
+}
+
+func TestJSONReporterRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (JSONReporter{}).Report(sampleTestSuite(), &buf); err != nil {
+		t.Fatalf("Report: %v", err)
+	}
+
+	var decoded []jsonTestResult
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("unmarshal report: %v", err)
+	}
+	if len(decoded) != 1 {
+		t.Fatalf("got %d results, want 1", len(decoded))
+	}
+	if decoded[0].Name != "default" || !decoded[0].Passed {
+		t.Errorf("got %+v, want name=default passed=true", decoded[0])
+	}
+	if len(decoded[0].Collectors) != 2 {
+		t.Fatalf("got %d collector results, want 2", len(decoded[0].Collectors))
+	}
+	if got := decoded[0].Collectors[1]; got.Up || got.Error != "chassis info unavailable" {
+		t.Errorf("collectors[1] = %+v, want up=false error=\"chassis info unavailable\"", got)
+	}
+}
+
+func TestJUnitReporterEmitsOneTestcasePerCollector(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (JUnitReporter{}).Report(sampleTestSuite(), &buf); err != nil {
+		t.Fatalf("Report: %v", err)
+	}
+
+	var suite junitTestSuite
+	if err := xml.Unmarshal(buf.Bytes(), &suite); err != nil {
+		t.Fatalf("unmarshal report: %v", err)
+	}
+	if suite.Tests != 2 {
+		t.Errorf("Tests = %d, want 2", suite.Tests)
+	}
+	if suite.Failures != 1 {
+		t.Errorf("Failures = %d, want 1", suite.Failures)
+	}
+	if len(suite.TestCases) != 2 || suite.TestCases[1].Failure == nil {
+		t.Fatalf("expected the second testcase (chassis) to carry a <failure>, got %+v", suite.TestCases)
+	}
+}
+
+func TestReporterForFallsBackToText(t *testing.T) {
+	cases := map[string]Reporter{
+		"json":         JSONReporter{},
+		"junit":        JUnitReporter{},
+		"text":         TextReporter{},
+		"":             TextReporter{},
+		"unrecognized": TextReporter{},
+	}
+	for format, want := range cases {
+		if got := ReporterFor(format); got != want {
+			t.Errorf("ReporterFor(%q) = %T, want %T", format, got, want)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright 2021 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/alecthomas/kingpin/v2"
+)
+
+var (
+	parallelism  = kingpin.Flag("parallelism", "Number of test cases to run concurrently during --test.").Default(fmt.Sprintf("%d", runtime.NumCPU())).Int()
+	testTimeout  = kingpin.Flag("test-timeout", "Per-test-case timeout during --test.").Default("30s").Duration()
+	suiteTimeout = kingpin.Flag("suite-timeout", "Overall --test suite timeout.").Default("10m").Duration()
+)
+
+// effectiveParallelism clamps --parallelism to at least 1: a zero value
+// would make RunAllTests' worker-pool channel never accept a sender, stalling
+// every test case until --suite-timeout fires, and a negative value panics
+// in make("makechan: size out of range").
+func effectiveParallelism() int {
+	if *parallelism < 1 {
+		return 1
+	}
+	return *parallelism
+}
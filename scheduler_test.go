@@ -0,0 +1,38 @@
+// Copyright 2021 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import "testing"
+
+func TestEffectiveParallelismClampsToAtLeastOne(t *testing.T) {
+	original := *parallelism
+	defer func() { *parallelism = original }()
+
+	cases := []struct {
+		flag int
+		want int
+	}{
+		{flag: 4, want: 4},
+		{flag: 1, want: 1},
+		{flag: 0, want: 1},
+		{flag: -3, want: 1},
+	}
+
+	for _, c := range cases {
+		*parallelism = c.flag
+		if got := effectiveParallelism(); got != c.want {
+			t.Errorf("effectiveParallelism() with --parallelism=%d = %d, want %d", c.flag, got, c.want)
+		}
+	}
+}
@@ -14,14 +14,17 @@
 package main
 
 import (
+	"context"
 	"fmt"
-	"log"
 	"log/slog"
-	"os"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/prometheus-community/ipmi_exporter/freeipmi"
+	"github.com/prometheus-community/ipmi_exporter/logging"
+	"github.com/prometheus-community/ipmi_exporter/nativeipmi"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -29,12 +32,21 @@ import (
 type TestCase struct {
 	Name        string
 	Description string
-	Collector   collector
+	Collectors  map[string]collector // collector name (as used in the "collector" label) -> implementation
 	Target      string
 	Module      string
 	Expected    string // What we expect to find (e.g., "sensor data", "chassis info")
 }
 
+// CollectorResult is the outcome of running a single named collector
+// (e.g. "bmc", "sensors", "sel") as part of a TestCase.
+type CollectorResult struct {
+	Name    string
+	Up      bool
+	Metrics int
+	Err     error
+}
+
 // TestResult represents the result of a single test case
 type TestResult struct {
 	TestCase     TestCase
@@ -45,174 +57,110 @@ type TestResult struct {
 	Trace        string
 	MetricsCount int
 	Metrics      []string // Store metric values for debug
+	Collectors   []CollectorResult
 }
 
+// upDesc is the "ipmi_up{collector=...}" gauge every collector reports,
+// independent of whatever domain-specific metrics it also emits. It lets one
+// failing collector (e.g. bmc-info unsupported on a given BMC) be visible
+// without hiding the metrics every other collector still produced.
+var upDesc = prometheus.NewDesc(
+	"ipmi_up",
+	"Whether a given collector successfully reported data ('1') or not ('0').",
+	[]string{"collector"}, nil,
+)
+
 // TestSuite manages and runs all IPMI tests
 type TestSuite struct {
-	config     *SafeConfig
-	results    []TestResult
-	logger     *log.Logger
-	slogLogger *slog.Logger
-	debug      bool
+	config  *SafeConfig
+	results []TestResult
+	logger  logging.Logger
+	debug   bool
 }
 
-// NewTestSuite creates a new test suite
-func NewTestSuite(config *SafeConfig, logger *log.Logger, debug bool) *TestSuite {
-	slogLogger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{}))
+// NewTestSuite creates a new test suite. logger is shared by the suite
+// itself, the native IPMI client, and (via the same interface) collectors
+// that have been updated to accept one; a nil logger falls back to a
+// slog.Logger configured from --log.level/--log.format.
+func NewTestSuite(config *SafeConfig, logger logging.Logger, debug bool) *TestSuite {
+	if logger == nil {
+		logger = newDefaultLogger()
+	}
 	return &TestSuite{
-		config:     config,
-		results:    make([]TestResult, 0),
-		logger:     logger,
-		slogLogger: slogLogger,
-		debug:      debug,
+		config:  config,
+		results: make([]TestResult, 0),
+		logger:  logger,
+		debug:   debug,
 	}
 }
 
-// GetAllTestCases returns all available test cases for both FreeIPMI and Native implementations
-func (ts *TestSuite) GetAllTestCases() []TestCase {
-	testCases := []TestCase{}
-
-	// FreeIPMI test cases
-	if !*nativeIPMI {
-		testCases = append(testCases,
-			TestCase{
-				Name:        "bmc_info",
-				Description: "Get BMC device information",
-				Collector:   &BMCCollector{},
-				Target:      targetLocal,
-				Module:      "default",
-				Expected:    "BMC device info",
-			},
-			TestCase{
-				Name:        "chassis_info",
-				Description: "Get chassis information",
-				Collector:   &ChassisCollector{},
-				Target:      targetLocal,
-				Module:      "default",
-				Expected:    "chassis info",
-			},
-			TestCase{
-				Name:        "dcmi_info",
-				Description: "Get DCMI power management information",
-				Collector:   &DCMICollector{},
-				Target:      targetLocal,
-				Module:      "default",
-				Expected:    "DCMI power data",
-			},
-			TestCase{
-				Name:        "ipmi_sensor",
-				Description: "Get IPMI sensor readings",
-				Collector:   &IPMICollector{},
-				Target:      targetLocal,
-				Module:      "default",
-				Expected:    "sensor readings",
-			},
-			TestCase{
-				Name:        "sel_info",
-				Description: "Get System Event Log information",
-				Collector:   &SELCollector{},
-				Target:      targetLocal,
-				Module:      "default",
-				Expected:    "SEL entries",
-			},
-			TestCase{
-				Name:        "sel_events",
-				Description: "Get System Event Log events",
-				Collector:   &SELEventsCollector{},
-				Target:      targetLocal,
-				Module:      "default",
-				Expected:    "SEL events",
-			},
-			TestCase{
-				Name:        "bmc_watchdog",
-				Description: "Get BMC watchdog timer information",
-				Collector:   &BMCWatchdogCollector{},
-				Target:      targetLocal,
-				Module:      "default",
-				Expected:    "watchdog info",
-			},
-			TestCase{
-				Name:        "sm_lan_mode",
-				Description: "Get shared memory LAN mode information",
-				Collector:   &SMLANModeCollector{},
-				Target:      targetLocal,
-				Module:      "default",
-				Expected:    "LAN mode data",
-			},
-		)
+// executorFor returns the Executor that should back a given test case's
+// collector: the real FreeIPMI tools by default, a recordingExecutor under
+// --record, or a FixtureExecutor under --replay. logger is the test case's
+// own sink adapted to a *slog.Logger (see runNative's use of the same
+// sink), so Debug logging done while executing the real FreeIPMI tools
+// lands in that test's TestResult.Trace instead of only the shared,
+// interleaved ts.logger.
+func (ts *TestSuite) executorFor(fixture string, logger *slog.Logger) Executor {
+	real := realExecutor{logger: logger}
+	switch {
+	case *replayFixtures:
+		return FixtureExecutor{fixture: fixture}
+	case *recordFixtures:
+		return recordingExecutor{inner: real, fixture: fixture}
+	default:
+		return real
 	}
+}
 
-	// Native IPMI test cases
+// GetAllTestCases returns a single TestCase, for the "default" module,
+// carrying a fixed set of every collector (the native ones when nativeIPMI
+// is set); the module's configuration is not consulted. This is so that
+// RunTest's partial-failure
+// tolerance (one down collector among several) is actually observable
+// instead of every TestCase having exactly one collector to pass or fail.
+func (ts *TestSuite) GetAllTestCases() []TestCase {
 	if *nativeIPMI {
-		testCases = append(testCases,
-			TestCase{
-				Name:        "bmc_info_native",
-				Description: "Get BMC device information (Native)",
-				Collector:   &BMCNativeCollector{},
-				Target:      targetLocal,
-				Module:      "default",
-				Expected:    "BMC device info",
-			},
-			TestCase{
-				Name:        "chassis_info_native",
-				Description: "Get chassis information (Native)",
-				Collector:   &ChassisNativeCollector{},
-				Target:      targetLocal,
-				Module:      "default",
-				Expected:    "chassis info",
-			},
-			TestCase{
-				Name:        "dcmi_info_native",
-				Description: "Get DCMI power management information (Native)",
-				Collector:   &DCMINativeCollector{},
-				Target:      targetLocal,
-				Module:      "default",
-				Expected:    "DCMI power data",
-			},
-			TestCase{
-				Name:        "ipmi_sensor_native",
-				Description: "Get IPMI sensor readings (Native)",
-				Collector:   &IPMINativeCollector{},
-				Target:      targetLocal,
-				Module:      "default",
-				Expected:    "sensor readings",
-			},
-			TestCase{
-				Name:        "sel_info_native",
-				Description: "Get System Event Log information (Native)",
-				Collector:   &SELNativeCollector{},
-				Target:      targetLocal,
-				Module:      "default",
-				Expected:    "SEL entries",
-			},
-			TestCase{
-				Name:        "sel_events_native",
-				Description: "Get System Event Log events (Native)",
-				Collector:   &SELEventsNativeCollector{},
-				Target:      targetLocal,
-				Module:      "default",
-				Expected:    "SEL events",
-			},
-			TestCase{
-				Name:        "bmc_watchdog_native",
-				Description: "Get BMC watchdog timer information (Native)",
-				Collector:   &BMCWatchdogNativeCollector{},
-				Target:      targetLocal,
-				Module:      "default",
-				Expected:    "watchdog info",
+		return []TestCase{
+			{
+				Name:        "default_native",
+				Description: "Run every configured collector for the default module (Native)",
+				Collectors: map[string]collector{
+					"bmc":         &BMCNativeCollector{},
+					"chassis":     &ChassisNativeCollector{},
+					"dcmi":        &DCMINativeCollector{},
+					"sensors":     &IPMINativeCollector{},
+					"sel":         &SELNativeCollector{},
+					"sel-events":  &SELEventsNativeCollector{},
+					"watchdog":    &BMCWatchdogNativeCollector{},
+					"sm-lan-mode": &SMLANModeNativeCollector{},
+				},
+				Target:   targetLocal,
+				Module:   "default",
+				Expected: "BMC device info, chassis info, DCMI power data, sensor readings, SEL entries/events, watchdog info, LAN mode data",
 			},
-			TestCase{
-				Name:        "sm_lan_mode_native",
-				Description: "Get shared memory LAN mode information (Native)",
-				Collector:   &SMLANModeNativeCollector{},
-				Target:      targetLocal,
-				Module:      "default",
-				Expected:    "LAN mode data",
-			},
-		)
+		}
 	}
 
-	return testCases
+	return []TestCase{
+		{
+			Name:        "default",
+			Description: "Run every configured collector for the default module",
+			Collectors: map[string]collector{
+				"bmc":         &BMCCollector{},
+				"chassis":     &ChassisCollector{},
+				"dcmi":        &DCMICollector{},
+				"sensors":     &IPMICollector{},
+				"sel":         &SELCollector{},
+				"sel-events":  &SELEventsCollector{},
+				"watchdog":    &BMCWatchdogCollector{},
+				"sm-lan-mode": &SMLANModeCollector{},
+			},
+			Target:   targetLocal,
+			Module:   "default",
+			Expected: "BMC device info, chassis info, DCMI power data, sensor readings, SEL entries/events, watchdog info, LAN mode data",
+		},
+	}
 }
 
 // DebugMetricCollector captures metric values for debugging
@@ -231,6 +179,38 @@ func (d *DebugMetricCollector) String() string {
 	return "debug_collector"
 }
 
+// runNative opens a native RMCP+ session against the test case's target and
+// issues the IPMI command appropriate for the named collector, returning the
+// result in the same freeipmi.Result shape the FreeIPMI-backed path uses.
+func (ts *TestSuite) runNative(testCase TestCase, collectorName string, ipmiConfig IPMIConfig, log logging.Logger) (freeipmi.Result, error) {
+	session, err := nativeipmi.Open(testCase.Target, ipmiConfig.User, ipmiConfig.Password, log)
+	if err != nil {
+		return freeipmi.Result{}, fmt.Errorf("open native IPMI session: %w", err)
+	}
+	defer session.Close()
+
+	switch collectorName {
+	case "bmc":
+		return session.GetDeviceID().ToFreeIPMIResult(), nil
+	case "chassis":
+		return session.GetChassisStatus().ToFreeIPMIResult(), nil
+	case "dcmi":
+		return session.GetDCMIPowerReading().ToFreeIPMIResult(), nil
+	case "sensors":
+		return session.GetSensorReadings().ToFreeIPMIResult(), nil
+	case "sel", "sel-events":
+		return session.GetSEL().ToFreeIPMIResult(), nil
+	case "watchdog":
+		return session.GetWatchdogTimer().ToFreeIPMIResult(), nil
+	case "sm-lan-mode":
+		// Shared-memory LAN mode is read directly out of BMC shared memory
+		// by FreeIPMI's sm-lan-mode tool; there is no RMCP+ command for it.
+		return freeipmi.Result{}, fmt.Errorf("sm-lan-mode has no native RMCP+ equivalent")
+	default:
+		return freeipmi.Result{}, fmt.Errorf("no native command mapped for collector %q", collectorName)
+	}
+}
+
 // RunTest executes a single test case
 func (ts *TestSuite) RunTest(testCase TestCase) TestResult {
 	start := time.Now()
@@ -245,221 +225,272 @@ func (ts *TestSuite) RunTest(testCase TestCase) TestResult {
 		Metrics:      make([]string, 0),
 	}
 
-	ts.logger.Printf("Running test: %s - %s", testCase.Name, testCase.Description)
-
-	// Create a real prometheus metrics channel
-	ch := make(chan prometheus.Metric, 100)
-	defer close(ch)
+	ts.logger.Info("running test", "name", testCase.Name, "description", testCase.Description)
 
-	// Create target
+	ipmiConfig := ts.config.C.Target(testCase.Target)
 	target := ipmiTarget{
 		host:   testCase.Target,
-		config: IPMIConfig{}, // Use empty config for testing
+		config: ipmiConfig,
 	}
 
-	// Execute the collector
-	var output freeipmi.Result
+	// sink captures this test's own Debug-level messages so result.Trace is
+	// self-contained even though ts.logger is shared across every test case
+	// RunAllTests runs concurrently.
+	sink := newTestSink(ts.logger)
+
+	anyUp := false
+	for _, name := range sortedCollectorNames(testCase.Collectors) {
+		collResult := ts.runCollector(&result, testCase, name, testCase.Collectors[name], ipmiConfig, target, sink)
+		result.Collectors = append(result.Collectors, collResult)
+		result.MetricsCount += collResult.Metrics
+		if collResult.Up {
+			anyUp = true
+		} else {
+			result.Trace += fmt.Sprintf("\n[%s] %v", name, collResult.Err)
+			result.Error = collResult.Err
+		}
+	}
+
+	// A test only fails outright if every one of its collectors failed; one
+	// missing sub-feature (e.g. bmc-watchdog unsupported) shouldn't hide the
+	// metrics the rest of the collectors still produced.
+	result.Passed = anyUp
+	result.Duration = time.Since(start)
+	result.Output = "test execution completed"
+
+	if debugTrace := sink.DebugTrace(); debugTrace != "" {
+		result.Trace += "\n" + debugTrace
+	}
+
+	if result.Trace == "" {
+		result.Trace = fmt.Sprintf("Collectors run: %d, metrics collected: %d", len(result.Collectors), result.MetricsCount)
+	}
+
+	return result
+}
+
+// runCollector executes a single named collector for testCase, always
+// emitting its ipmi_up{collector=name} gauge regardless of outcome, and
+// returns that collector's CollectorResult.
+func (ts *TestSuite) runCollector(result *TestResult, testCase TestCase, name string, coll collector, ipmiConfig IPMIConfig, target ipmiTarget, sink *testSink) CollectorResult {
+	ch := make(chan prometheus.Metric, 100)
+	defer close(ch)
 
-	// Execute command based on collector type
+	var output freeipmi.Result
+	var err error
 	if strings.HasSuffix(testCase.Name, "_native") {
-		// Native IPMI execution would go here
-		// For now, we'll simulate it with a mock result
-		output = freeipmi.Result{}
-		// We can't set unexported fields, so we'll handle this in the collection phase
+		output, err = ts.runNative(testCase, name, ipmiConfig, sink)
 	} else {
-		// FreeIPMI execution
-		cmd := testCase.Collector.Cmd()
-		args := testCase.Collector.Args()
-
-		// Execute the command
-		output = freeipmi.Execute(cmd, args, "", testCase.Target, ts.slogLogger)
-		// Check if there was an error by trying to access the result
-		// Since we can't access unexported fields, we'll handle this differently
+		cmd := coll.Cmd()
+		args := coll.Args()
+		executor := ts.executorFor(testCase.Name+"_"+name, sink.SlogLogger())
+		output = executor.Execute(cmd, args, "", testCase.Target)
 	}
 
-	// Collect metrics
 	metricsCount := 0
-	count, collectErr := testCase.Collector.Collect(output, ch, target)
-	if collectErr != nil {
-		result.Error = collectErr
-		result.Trace += fmt.Sprintf("\nCollection failed: %v", collectErr)
-	} else {
-		metricsCount = count
-
-		// Capture metric values if debug mode is enabled
-		if ts.debug {
-			for i := 0; i < metricsCount; i++ {
-				metric := <-ch
-				// Convert metric to string representation
-				desc := metric.Desc()
-				result.Metrics = append(result.Metrics, fmt.Sprintf("Metric: %s", desc))
-
-				// Create a simple, readable metric summary
-				var metricInfo strings.Builder
-
-				// Extract metric name from description
-				descStr := desc.String()
-				if strings.Contains(descStr, "fqName:") {
-					start := strings.Index(descStr, "fqName: \"") + 9
-					end := strings.Index(descStr[start:], "\"")
-					if end != -1 {
-						metricName := descStr[start : start+end]
-						metricInfo.WriteString(fmt.Sprintf("Metric Name: %s\n", metricName))
-					}
-				}
+	if err == nil {
+		metricsCount, err = coll.Collect(output, ch, target)
+	}
 
-				// Extract help text
-				if strings.Contains(descStr, "help:") {
-					start := strings.Index(descStr, "help: \"") + 7
-					end := strings.Index(descStr[start:], "\"")
-					if end != -1 {
-						helpText := descStr[start : start+end]
-						metricInfo.WriteString(fmt.Sprintf("Description: %s\n", helpText))
-					}
-				}
+	up := err == nil
+	ch <- prometheus.MustNewConstMetric(upDesc, prometheus.GaugeValue, boolToFloat64(up), name)
+
+	if err != nil {
+		if isExpectedMissing(err) {
+			sink.Debug("collector feature unavailable", "collector", name, "test", testCase.Name, "err", err)
+		} else {
+			sink.Error("collector failed", "collector", name, "test", testCase.Name, "err", err)
+		}
+	}
 
-				// Extract variable labels
-				if strings.Contains(descStr, "variableLabels:") {
-					start := strings.Index(descStr, "variableLabels:") + 15
-					end := strings.Index(descStr[start:], "}")
-					if end != -1 {
-						labelsStr := descStr[start : start+end]
-						labelsStr = strings.TrimSpace(labelsStr)
-						if labelsStr != "" && labelsStr != "{}" {
-							metricInfo.WriteString(fmt.Sprintf("Available Labels: %s\n", labelsStr))
-						}
+	if ts.debug {
+		for i := 0; i < metricsCount+1; i++ {
+			metric := <-ch
+			desc := metric.Desc()
+			result.Metrics = append(result.Metrics, fmt.Sprintf("Metric: %s", desc))
+
+			var metricInfo strings.Builder
+			descStr := desc.String()
+			if strings.Contains(descStr, "fqName:") {
+				start := strings.Index(descStr, "fqName: \"") + 9
+				end := strings.Index(descStr[start:], "\"")
+				if end != -1 {
+					metricName := descStr[start : start+end]
+					metricInfo.WriteString(fmt.Sprintf("Metric Name: %s\n", metricName))
+				}
+			}
+			if strings.Contains(descStr, "help:") {
+				start := strings.Index(descStr, "help: \"") + 7
+				end := strings.Index(descStr[start:], "\"")
+				if end != -1 {
+					helpText := descStr[start : start+end]
+					metricInfo.WriteString(fmt.Sprintf("Description: %s\n", helpText))
+				}
+			}
+			if strings.Contains(descStr, "variableLabels:") {
+				start := strings.Index(descStr, "variableLabels:") + 15
+				end := strings.Index(descStr[start:], "}")
+				if end != -1 {
+					labelsStr := descStr[start : start+end]
+					labelsStr = strings.TrimSpace(labelsStr)
+					if labelsStr != "" && labelsStr != "{}" {
+						metricInfo.WriteString(fmt.Sprintf("Available Labels: %s\n", labelsStr))
 					}
 				}
+			}
 
-				// Show actual IPMI command output for debugging
-				metricInfo.WriteString("IPMI Command Output:\n")
-				cmd := testCase.Collector.Cmd()
-				args := testCase.Collector.Args()
-				metricInfo.WriteString(fmt.Sprintf("  Command: %s\n", cmd))
-				metricInfo.WriteString(fmt.Sprintf("  Args: %v\n", args))
-
-				// Show the raw FreeIPMI output
-				ipmiOutput := freeipmi.Execute(cmd, args, "", testCase.Target, ts.slogLogger)
-				metricInfo.WriteString(fmt.Sprintf("  Raw Output: %+v\n", ipmiOutput))
+			metricInfo.WriteString("IPMI Command Output:\n")
+			cmd := coll.Cmd()
+			args := coll.Args()
+			metricInfo.WriteString(fmt.Sprintf("  Command: %s\n", cmd))
+			metricInfo.WriteString(fmt.Sprintf("  Args: %v\n", args))
+			metricInfo.WriteString(fmt.Sprintf("  Raw Output: %+v\n", output))
 
-				result.Metrics = append(result.Metrics, metricInfo.String())
-			}
-		} else {
-			// Just drain the channel to prevent deadlock
-			for i := 0; i < metricsCount; i++ {
-				<-ch
-			}
+			result.Metrics = append(result.Metrics, metricInfo.String())
+		}
+	} else {
+		for i := 0; i < metricsCount+1; i++ {
+			<-ch
 		}
 	}
 
-	// Determine if test passed
-	result.Passed = result.Error == nil && metricsCount > 0
-	result.Duration = time.Since(start)
-	result.MetricsCount = metricsCount
-
-	// We can't access the output field directly, so we'll use a placeholder
-	result.Output = "test execution completed"
+	return CollectorResult{Name: name, Up: up, Metrics: metricsCount, Err: err}
+}
 
-	// Add trace information
-	if result.Trace == "" {
-		result.Trace = fmt.Sprintf("Command: %s %v\nMetrics collected: %d",
-			testCase.Collector.Cmd(), testCase.Collector.Args(), metricsCount)
+func boolToFloat64(b bool) float64 {
+	if b {
+		return 1
 	}
+	return 0
+}
 
-	return result
+// sortedCollectorNames returns the keys of a TestCase's Collectors map in a
+// deterministic order, so runs of the same test case always report their
+// collectors (and hence their debug output and trace) in the same sequence.
+func sortedCollectorNames(collectors map[string]collector) []string {
+	names := make([]string, 0, len(collectors))
+	for name := range collectors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
 }
 
 // RunAllTests executes all test cases
 func (ts *TestSuite) RunAllTests() {
 	testCases := ts.GetAllTestCases()
-	ts.logger.Printf("Starting comprehensive IPMI test suite - total tests: %d", len(testCases))
+	workers := effectiveParallelism()
+	ts.logger.Info("starting comprehensive IPMI test suite", "total_tests", len(testCases), "parallelism", workers)
+
+	ctx, cancel := context.WithTimeout(context.Background(), *suiteTimeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex // guards ts.results and the result-logging below
+	sem := make(chan struct{}, workers)
 
 	for _, testCase := range testCases {
-		result := ts.RunTest(testCase)
-		ts.results = append(ts.results, result)
+		testCase := testCase
 
-		// Log immediate result
-		if result.Passed {
-			ts.logger.Printf("Test PASSED: %s (duration: %v, metrics: %d)", testCase.Name, result.Duration, result.MetricsCount)
-		} else {
-			ts.logger.Printf("Test FAILED: %s (duration: %v, error: %v)", testCase.Name, result.Duration, result.Error)
-			// Print trace for failed tests
-			fmt.Printf("\n=== FAILED TEST TRACE: %s ===\n", testCase.Name)
-			fmt.Printf("%s\n", result.Trace)
-			fmt.Printf("=== END TRACE ===\n\n")
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			ts.logger.Warn("suite timeout reached, not starting test", "name", testCase.Name)
+			continue
 		}
 
-		// Show debug information if enabled
-		if ts.debug && len(result.Metrics) > 0 {
-			fmt.Printf("\n=== DEBUG METRICS: %s ===\n", testCase.Name)
-			for i, metric := range result.Metrics {
-				if strings.HasPrefix(metric, "Metric:") {
-					fmt.Printf("%s\n", metric)
-				} else {
-					// This is the detailed metric info
-					fmt.Printf("%s", metric)
-					if i < len(result.Metrics)-1 && !strings.HasPrefix(result.Metrics[i+1], "Metric:") {
-						fmt.Printf("---\n")
-					}
-				}
-			}
-			fmt.Printf("=== END DEBUG METRICS ===\n\n")
-		}
-	}
-}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
 
-// PrintResultsTable displays a formatted results table
-func (ts *TestSuite) PrintResultsTable() {
-	fmt.Printf("\n========================================================================================================================\n")
-	fmt.Printf("%-25s %-35s %-8s %-12s %-10s %-15s\n", "TEST NAME", "DESCRIPTION", "STATUS", "DURATION", "METRICS", "ERROR")
-	fmt.Printf("------------------------------------------------------------------------------------------------------------------------\n")
+			result := ts.runTestWithTimeout(ctx, testCase)
 
-	passed := 0
-	failed := 0
-	totalDuration := time.Duration(0)
+			mu.Lock()
+			defer mu.Unlock()
+			ts.results = append(ts.results, result)
+			ts.logTestResult(testCase, result)
+		}()
+	}
 
-	for _, result := range ts.results {
-		status := "FAIL"
-		statusColor := "\033[31m" // Red
-		if result.Passed {
-			status = "PASS"
-			statusColor = "\033[32m" // Green
-			passed++
-		} else {
-			failed++
-		}
+	wg.Wait()
 
-		errorMsg := ""
-		if result.Error != nil {
-			errorMsg = result.Error.Error()
-			if len(errorMsg) > 15 {
-				errorMsg = errorMsg[:12] + "..."
-			}
-		}
+	// Dispatch order is nondeterministic under the worker pool above;
+	// PrintResultsTable and the Reporters all expect a stable ordering.
+	sort.Slice(ts.results, func(i, j int) bool {
+		return ts.results[i].TestCase.Name < ts.results[j].TestCase.Name
+	})
+}
 
-		// Truncate description if too long
-		description := result.TestCase.Description
-		if len(description) > 35 {
-			description = description[:32] + "..."
+// runTestWithTimeout runs a single test case on its own goroutine and
+// enforces --test-timeout and the overall suite deadline around it. RunTest
+// itself has no notion of context cancellation, so a timed-out test's
+// goroutine is abandoned rather than interrupted; this bounds how long
+// RunAllTests waits on a wedged target without requiring every collector and
+// transport to be made context-aware.
+func (ts *TestSuite) runTestWithTimeout(ctx context.Context, testCase TestCase) TestResult {
+	resultCh := make(chan TestResult, 1)
+	go func() {
+		resultCh <- ts.RunTest(testCase)
+	}()
+
+	timer := time.NewTimer(*testTimeout)
+	defer timer.Stop()
+
+	select {
+	case result := <-resultCh:
+		return result
+	case <-timer.C:
+		return TestResult{
+			TestCase: testCase,
+			Passed:   false,
+			Error:    fmt.Errorf("test case %q exceeded --test-timeout (%s)", testCase.Name, *testTimeout),
+			Trace:    fmt.Sprintf("test case did not complete within %s", *testTimeout),
 		}
+	case <-ctx.Done():
+		return TestResult{
+			TestCase: testCase,
+			Passed:   false,
+			Error:    fmt.Errorf("test case %q aborted: %w", testCase.Name, ctx.Err()),
+		}
+	}
+}
 
-		fmt.Printf("%-25s %-35s %s%-8s\033[0m %-12v %-10d %-15s\n",
-			result.TestCase.Name,
-			description,
-			statusColor, status,
-			result.Duration,
-			result.MetricsCount,
-			errorMsg,
-		)
+// logTestResult prints the immediate pass/fail line and, on failure or in
+// debug mode, the trace/metric dump for a single completed test case.
+func (ts *TestSuite) logTestResult(testCase TestCase, result TestResult) {
+	if result.Passed {
+		ts.logger.Info("test passed", "name", testCase.Name, "duration", result.Duration, "metrics", result.MetricsCount)
+	} else {
+		ts.logger.Error("test failed", "name", testCase.Name, "duration", result.Duration, "err", result.Error)
+		fmt.Printf("\n=== FAILED TEST TRACE: %s ===\n", testCase.Name)
+		fmt.Printf("%s\n", result.Trace)
+		fmt.Printf("=== END TRACE ===\n\n")
+	}
 
-		totalDuration += result.Duration
+	if ts.debug && len(result.Metrics) > 0 {
+		fmt.Printf("\n=== DEBUG METRICS: %s ===\n", testCase.Name)
+		for i, metric := range result.Metrics {
+			if strings.HasPrefix(metric, "Metric:") {
+				fmt.Printf("%s\n", metric)
+			} else {
+				fmt.Printf("%s", metric)
+				if i < len(result.Metrics)-1 && !strings.HasPrefix(result.Metrics[i+1], "Metric:") {
+					fmt.Printf("---\n")
+				}
+			}
+		}
+		fmt.Printf("=== END DEBUG METRICS ===\n\n")
 	}
+}
 
-	fmt.Printf("------------------------------------------------------------------------------------------------------------------------\n")
-	fmt.Printf("SUMMARY: %d PASSED, %d FAILED, %d TOTAL\n", passed, failed, len(ts.results))
-	fmt.Printf("TOTAL DURATION: %v\n", totalDuration)
-	fmt.Printf("IMPLEMENTATION: %s\n", map[bool]string{true: "Native IPMI", false: "FreeIPMI"}[*nativeIPMI])
-	fmt.Printf("========================================================================================================================\n")
+// PrintResultsTable renders the suite's results per --report-format,
+// defaulting to the ANSI-colored table on stdout, and to --report-file
+// instead of stdout when that flag is set.
+func (ts *TestSuite) PrintResultsTable() {
+	if err := WriteReport(ts, *reportFormat, *reportFile); err != nil {
+		ts.logger.Error("failed to write test report", "err", err)
+	}
 }
 
 // GetSummary returns a summary of test results